@@ -0,0 +1,127 @@
+package contract
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// WasmStoreKeyPrefix is the prefix byte under which the x/wasm module stores
+// per-contract state in the multistore.
+const WasmStoreKeyPrefix = byte(0x03)
+
+// GetWasmWhitelistedPrefixes derives the wasm multistore key prefix for a
+// single contract address, granting access to the entirety of that
+// contract's state.
+func GetWasmWhitelistedPrefixes(contractAddr string) []string {
+	addr, err := sdk.AccAddressFromBech32(contractAddr)
+	if err != nil {
+		panic(err)
+	}
+	return []string{string(append([]byte{WasmStoreKeyPrefix}, addr.Bytes()...))}
+}
+
+// InvalidWhitelistAddressError is returned by GetWasmWhitelistedPrefixesBatch
+// when one of the provided addresses fails bech32 account address
+// validation, so callers can identify exactly which entry is malformed.
+type InvalidWhitelistAddressError struct {
+	Index   int
+	Address string
+	Err     error
+}
+
+func (e *InvalidWhitelistAddressError) Error() string {
+	return fmt.Sprintf("invalid whitelisted contract address at index %d (%q): %s", e.Index, e.Address, e.Err)
+}
+
+func (e *InvalidWhitelistAddressError) Unwrap() error {
+	return e.Err
+}
+
+// GetWasmWhitelistedPrefixesBatch validates and derives the wasm store key
+// prefixes for a batch of contract addresses. Every address must be a valid
+// bech32 account address or a *InvalidWhitelistAddressError identifying the
+// offending index is returned. Duplicate addresses are collapsed and the
+// returned prefixes are sorted into a stable order so callers can treat the
+// result as a deterministic whitelist set in BeginBlock/EndBlock.
+func GetWasmWhitelistedPrefixesBatch(addrs []string) ([][]byte, error) {
+	seen := make(map[string]struct{}, len(addrs))
+	prefixes := make([][]byte, 0, len(addrs))
+	for i, a := range addrs {
+		addr, err := sdk.AccAddressFromBech32(a)
+		if err != nil {
+			return nil, &InvalidWhitelistAddressError{Index: i, Address: a, Err: err}
+		}
+		key := string(addr.Bytes())
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		prefixes = append(prefixes, append([]byte{WasmStoreKeyPrefix}, addr.Bytes()...))
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		return bytes.Compare(prefixes[i], prefixes[j]) < 0
+	})
+	return prefixes, nil
+}
+
+// MustGetWasmWhitelistedPrefixesBatch is like GetWasmWhitelistedPrefixesBatch
+// but panics on error. It is intended for genesis-time initialization, where
+// an invalid whitelist entry indicates a malformed genesis file and should
+// halt startup immediately rather than propagate an error through init
+// genesis plumbing.
+func MustGetWasmWhitelistedPrefixesBatch(addrs []string) [][]byte {
+	prefixes, err := GetWasmWhitelistedPrefixesBatch(addrs)
+	if err != nil {
+		panic(err)
+	}
+	return prefixes
+}
+
+// WasmSubPrefixLength is the number of bytes of the namespace/index hash
+// appended to a contract's prefix when deriving a sub-prefix. This keeps
+// sub-prefixes short while leaving collisions astronomically unlikely for
+// the handful of namespaces a single contract is expected to whitelist.
+const WasmSubPrefixLength = 8
+
+// GetWasmWhitelistedSubPrefix derives a deterministic sub-prefix of a
+// contract's wasm store prefix, scoped to a single cw-storage-plus
+// namespace (e.g. "orders", "positions") and an optional shard index. This
+// lets a dex operator whitelist access to specific maps within a contract
+// instead of its entire state.
+//
+// The sub-prefix is consensus-visible and MUST remain stable across
+// releases: 0x03 || addr.Bytes() || sha256(namespace || indexLE)[:8]. It
+// shares its leading 0x03 || addr.Bytes() with GetWasmWhitelistedPrefixes so
+// that it is a genuine byte-prefix of the contract's real wasm store range.
+func GetWasmWhitelistedSubPrefix(contractAddr string, namespace []byte, index uint64) []byte {
+	addr, err := sdk.AccAddressFromBech32(contractAddr)
+	if err != nil {
+		panic(err)
+	}
+
+	indexBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(indexBytes, index)
+	salt := sha256.Sum256(append(append([]byte{}, namespace...), indexBytes...))
+
+	subPrefix := make([]byte, 0, 1+len(addr.Bytes())+WasmSubPrefixLength)
+	subPrefix = append(subPrefix, WasmStoreKeyPrefix)
+	subPrefix = append(subPrefix, addr.Bytes()...)
+	subPrefix = append(subPrefix, salt[:WasmSubPrefixLength]...)
+	return subPrefix
+}
+
+// GetWasmWhitelistedPrefixesForNamespaces derives sub-prefixes (at index 0)
+// for each of the given namespaces under a single contract, so a dex
+// operator can whitelist several cw-storage-plus maps at once.
+func GetWasmWhitelistedPrefixesForNamespaces(contractAddr string, ns [][]byte) [][]byte {
+	prefixes := make([][]byte, len(ns))
+	for i, namespace := range ns {
+		prefixes[i] = GetWasmWhitelistedSubPrefix(contractAddr, namespace, 0)
+	}
+	return prefixes
+}
@@ -1,6 +1,7 @@
 package contract_test
 
 import (
+	"bytes"
 	"encoding/hex"
 	"testing"
 
@@ -8,10 +9,121 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+const (
+	addr1 = "sei14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9sh9m79m"
+	addr2 = "sei1zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zygskw0yqp"
+)
+
 func TestGetWasmPrefixes(t *testing.T) {
-	wasmWhitelistedPrefixes := contract.GetWasmWhitelistedPrefixes("sei14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9sh9m79m")
+	wasmWhitelistedPrefixes := contract.GetWasmWhitelistedPrefixes(addr1)
 
 	wasmPrefixBytes, _ := hex.DecodeString("03" + "ade4a5f5803a439835c636395a8d648dee57b2fc90d98dc17fa887159b69638b")
 	require.Equal(t, []byte(wasmWhitelistedPrefixes[0]), wasmPrefixBytes)
 }
 
+func TestGetWasmWhitelistedPrefixesBatch(t *testing.T) {
+	prefix1 := []byte(contract.GetWasmWhitelistedPrefixes(addr1)[0])
+	prefix2 := []byte(contract.GetWasmWhitelistedPrefixes(addr2)[0])
+	sortedPrefixes := [][]byte{prefix1, prefix2}
+	if bytes.Compare(prefix2, prefix1) < 0 {
+		sortedPrefixes = [][]byte{prefix2, prefix1}
+	}
+
+	tests := []struct {
+		name    string
+		addrs   []string
+		want    [][]byte
+		wantErr bool
+		errIdx  int
+	}{
+		{
+			name:  "empty list",
+			addrs: []string{},
+			want:  [][]byte{},
+		},
+		{
+			name:  "single address",
+			addrs: []string{addr1},
+			want:  [][]byte{prefix1},
+		},
+		{
+			name:  "deduplicates repeated addresses",
+			addrs: []string{addr1, addr2, addr1},
+			want:  sortedPrefixes,
+		},
+		{
+			name:    "invalid bech32",
+			addrs:   []string{addr1, "not-a-bech32-address"},
+			wantErr: true,
+			errIdx:  1,
+		},
+		{
+			name:    "wrong hrp",
+			addrs:   []string{"cosmos1zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zygs5u086e"},
+			wantErr: true,
+			errIdx:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := contract.GetWasmWhitelistedPrefixesBatch(tt.addrs)
+			if tt.wantErr {
+				require.Error(t, err)
+				var invalidErr *contract.InvalidWhitelistAddressError
+				require.ErrorAs(t, err, &invalidErr)
+				require.Equal(t, tt.errIdx, invalidErr.Index)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMustGetWasmWhitelistedPrefixesBatchPanicsOnInvalidAddress(t *testing.T) {
+	require.Panics(t, func() {
+		contract.MustGetWasmWhitelistedPrefixesBatch([]string{"not-a-bech32-address"})
+	})
+}
+
+func TestGetWasmWhitelistedSubPrefix(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace []byte
+		index     uint64
+		want      string
+	}{
+		{
+			name:      "orders at index 0",
+			namespace: []byte("orders"),
+			index:     0,
+			want:      "03ade4a5f5803a439835c636395a8d648dee57b2fc90d98dc17fa887159b69638ba7617b4a2d5f15ef",
+		},
+		{
+			name:      "positions at index 7",
+			namespace: []byte("positions"),
+			index:     7,
+			want:      "03ade4a5f5803a439835c636395a8d648dee57b2fc90d98dc17fa887159b69638bf017c5bd2b6c2586",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := hex.DecodeString(tt.want)
+			require.NoError(t, err)
+			got := contract.GetWasmWhitelistedSubPrefix(addr1, tt.namespace, tt.index)
+			require.Equal(t, want, got)
+		})
+	}
+}
+
+func TestGetWasmWhitelistedPrefixesForNamespaces(t *testing.T) {
+	ns := [][]byte{[]byte("orders"), []byte("positions")}
+	got := contract.GetWasmWhitelistedPrefixesForNamespaces(addr1, ns)
+	require.Len(t, got, 2)
+	require.Equal(t, contract.GetWasmWhitelistedSubPrefix(addr1, ns[0], 0), got[0])
+	require.Equal(t, contract.GetWasmWhitelistedSubPrefix(addr1, ns[1], 0), got[1])
+
+	require.False(t, bytes.Equal(got[0], got[1]), "distinct namespaces must derive distinct sub-prefixes")
+}